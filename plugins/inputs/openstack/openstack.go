@@ -2,23 +2,46 @@ package openstack
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/apiversions"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/schedulerstats"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/volumetenants"
+	volumesv1 "github.com/gophercloud/gophercloud/openstack/blockstorage/v1/volumes"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v2/volumes"
+	volumesv3 "github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
 	"github.com/influxdata/telegraf"
+	tlsint "github.com/influxdata/telegraf/internal/tls"
 	"github.com/influxdata/telegraf/plugins/inputs"
+	"golang.org/x/sync/errgroup"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
 )
 
+// defaultMaxConcurrentRequests bounds how many get* calls run at once when
+// max_concurrent_requests isn't configured.
+const defaultMaxConcurrentRequests = 6
+
+// allServices is the full, ordered set of services gathered when
+// enabled_services isn't configured.
+var allServices = []string{"identity", "compute", "hypervisor", "volume", "storage_pool", "network", "baremetal"}
+
 // Typedef for InfluxDB tags
 type TagMap map[string]string
 
@@ -55,6 +78,24 @@ type VolumeList []Volume
 // Typedef for OpenStack storage pools
 type StoragePoolList []schedulerstats.StoragePool
 
+// Typedef for OpenStack networks
+type NetworkList []networks.Network
+
+// Typedef for OpenStack subnets
+type SubnetList []subnets.Subnet
+
+// Typedef for OpenStack routers
+type RouterList []routers.Router
+
+// Typedef for OpenStack floating IPs
+type FloatingIPList []floatingips.FloatingIP
+
+// Typedef for OpenStack security groups
+type SecurityGroupList []groups.SecGroup
+
+// Typedef for OpenStack Ironic bare-metal nodes
+type NodeList []nodes.Node
+
 // Module configuration structure
 type OpenStack struct {
 	IdentityEndpoint string
@@ -62,6 +103,43 @@ type OpenStack struct {
 	Project          string
 	Username         string
 	Password         string
+
+	// Regions to collect metrics from. When empty, the default region for
+	// the authenticated token is used.
+	Regions []string
+
+	// EndpointType is the catalog interface used to resolve each service's
+	// endpoint: "public", "internal", or "admin". Defaults to "public".
+	EndpointType string
+
+	// BlockStorageAPIVersion selects the Cinder API version to talk to:
+	// "auto", "v1", "v2", or "v3". Defaults to "auto", which probes the
+	// volume service's apiversions endpoint and uses the highest version
+	// it reports as supported.
+	BlockStorageAPIVersion string
+
+	// resolvedBlockStorageAPIVersion caches the outcome of "auto" detection
+	// per region, so each region is only probed once rather than on every
+	// Gather cycle, and a mixed-version cloud doesn't have one region's
+	// result bleed into another's.
+	resolvedBlockStorageAPIVersion map[string]string
+
+	// TLS configuration, exposing insecure_skip_verify/tls_ca/tls_cert/tls_key
+	tlsint.ClientConfig
+
+	// CABundlePath is an additional CA bundle, in PEM format, appended to
+	// the system cert pool alongside tls_ca. It has no env var fallback;
+	// OS_CACERT stands in for tls_ca instead.
+	CABundlePath string
+
+	// MaxConcurrentRequests bounds how many get* calls run concurrently per
+	// region. Defaults to defaultMaxConcurrentRequests.
+	MaxConcurrentRequests int
+
+	// EnabledServices selects which of "identity", "compute", "hypervisor",
+	// "volume", "storage_pool", "network", and "baremetal" to gather.
+	// Defaults to all of them.
+	EnabledServices []string
 }
 
 // Convert a numeric field map into a native telegraf field map
@@ -97,6 +175,47 @@ var sampleConfig = `
 
   ## [REQUIRED] The user's password to authenticate with
   password = "Passw0rd"
+
+  ## [OPTIONAL] The regions to collect metrics from. When a cloud's catalog
+  ## exposes endpoints in more than one region, every region listed here is
+  ## gathered and every metric is tagged with its region. Defaults to the
+  ## region selected by the token scope.
+  # regions = ["RegionOne", "RegionTwo"]
+
+  ## [OPTIONAL] The catalog endpoint interface to use when resolving each
+  ## service's endpoint: "public", "internal", or "admin". Defaults to
+  ## 'public'
+  # endpoint_type = "public"
+
+  ## [OPTIONAL] The Cinder API version to use: "auto", "v1", "v2", or "v3".
+  ## "auto" probes the volume service's apiversions endpoint and uses the
+  ## highest version it reports as supported. Defaults to 'auto'
+  # block_storage_api_version = "auto"
+
+  ## [OPTIONAL] TLS configuration for talking to the identity endpoint and
+  ## every service in the catalog. The system cert pool is trusted by
+  ## default; tls_ca (or the OS_CACERT env var) adds to it instead of
+  ## replacing it.
+  # tls_ca = "/etc/telegraf/ca.pem"
+  # tls_cert = "/etc/telegraf/cert.pem"
+  # tls_key = "/etc/telegraf/key.pem"
+  # insecure_skip_verify = false
+
+  ## [OPTIONAL] An additional CA bundle, in PEM format, to trust. Useful
+  ## when a cloud's private PKI issues from more than one root.
+  # ca_bundle_path = "/etc/telegraf/ca-bundle.pem"
+
+  ## [OPTIONAL] The number of get* calls allowed to run concurrently per
+  ## region. Defaults to 6.
+  # max_concurrent_requests = 6
+
+  ## [OPTIONAL] The services to collect metrics from: "identity", "compute",
+  ## "hypervisor", "volume", "storage_pool", "network", and "baremetal".
+  ## Defaults to all of them. Trim this on clouds where a service is
+  ## unavailable, or where a non-admin tenant can't reach it (e.g.
+  ## schedulerstats). Compute, volume, and network metrics are tagged by
+  ## project, so they are only emitted when "identity" is enabled as well.
+  # enabled_services = ["identity", "compute", "hypervisor", "volume", "storage_pool", "network", "baremetal"]
 `
 // TODO switch godep to gophercloud recent commit / release
 // TODO find another sample config to model after, remove required/optional
@@ -111,83 +230,455 @@ func init() {
 	})
 }
 
-func (o *OpenStack) Gather(acc telegraf.Accumulator) error {
+// regions returns the configured regions to gather, falling back to a
+// single pass over the token-scoped default region when none are set.
+func (o *OpenStack) regions() []string {
+	if len(o.Regions) == 0 {
+		return []string{""}
+	}
+	return o.Regions
+}
 
-	// Authenticate against Keystone and get a token provider
-	authOptions := gophercloud.AuthOptions{
-		IdentityEndpoint: o.IdentityEndpoint,
-		DomainName:       o.Domain,
-		TenantName:       o.Project,
-		Username:         o.Username,
-		Password:         o.Password,
+// availability maps the configured endpoint_type onto the gophercloud
+// Availability used to resolve endpoints from the service catalog.
+func (o *OpenStack) availability() gophercloud.Availability {
+	switch o.EndpointType {
+	case "internal":
+		return gophercloud.AvailabilityInternal
+	case "admin":
+		return gophercloud.AvailabilityAdmin
+	default:
+		return gophercloud.AvailabilityPublic
 	}
+}
 
-	provider, err := openstack.AuthenticatedClient(authOptions)
-	if err != nil {
-		return fmt.Errorf("Unable to authenticate OpenStack user: %v", err)
+// enabledServices returns the set of services to gather this cycle,
+// defaulting to allServices when enabled_services isn't configured.
+func (o *OpenStack) enabledServices() map[string]bool {
+	list := o.EnabledServices
+	if len(list) == 0 {
+		list = allServices
 	}
 
-	// Don't validate x509 cert for testing
-	// TODO We shouldn't have to do this ... Seems like certs in dev
-	// environment may be misconfigured, or we're not passing the right config into the
-	// telegraf image.
-	// TODO Why are Identity calls succeeding but not others unless this is
-	// done?
-	// TODO Why do version checks succeed?
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	enabled := map[string]bool{}
+	for _, service := range list {
+		enabled[service] = true
 	}
-	provider.HTTPClient = http.Client{Transport: tr}
+	return enabled
+}
 
-	// Gather resources
-	// Don't bomb out here, some data is better than none, the 'gather'
-	// functions will check for validity before continuing
-	projectMap, err := getProjectMap(provider)
-	if err != nil {
-		log.Println("W! failed to get projects: " + err.Error())
+// maxConcurrentRequests returns the configured fan-out bound, or
+// defaultMaxConcurrentRequests when unset.
+func (o *OpenStack) maxConcurrentRequests() int {
+	if o.MaxConcurrentRequests <= 0 {
+		return defaultMaxConcurrentRequests
 	}
-	hypervisorList, err := getHypervisorList(provider)
-	if err != nil {
-		log.Println("W! failed to get hypervisors: " + err.Error())
+	return o.MaxConcurrentRequests
+}
+
+// resolveBlockStorageAPIVersion determines which Cinder API version to talk
+// to, caching the result per region on the OpenStack struct so later Gather
+// cycles don't re-probe the catalog.
+func (o *OpenStack) resolveBlockStorageAPIVersion(provider *gophercloud.ProviderClient, eo gophercloud.EndpointOpts, region string) (string, error) {
+	if v, ok := o.resolvedBlockStorageAPIVersion[region]; ok {
+		return v, nil
+	}
+
+	version := o.BlockStorageAPIVersion
+	if version == "" {
+		version = "auto"
+	}
+	if version != "auto" {
+		o.setResolvedBlockStorageAPIVersion(region, version)
+		return version, nil
 	}
-	flavorMap, err := getFlavorMap(provider)
+
+	volume, err := probeBlockStorageClient(provider, eo)
 	if err != nil {
-		log.Println("W! failed to get flavors: " + err.Error())
+		return "", fmt.Errorf("unable to create volume client to probe API versions: %v", err)
 	}
-	serverList, err := getServerList(provider)
+
+	page, err := apiversions.List(volume).AllPages()
 	if err != nil {
-		log.Println("W! failed to get servers: " + err.Error())
+		return "", fmt.Errorf("unable to list volume API versions: %v", err)
 	}
-	volumeList, err := getVolumeList(provider)
+
+	apiVersionList, err := apiversions.ExtractAPIVersions(page)
 	if err != nil {
-		log.Println("W! failed to get volumes: " + err.Error())
+		return "", fmt.Errorf("unable to extract volume API versions: %v", err)
 	}
-	storagePoolList, err := getStoragePools(provider)
+
+	// Pick the highest supported version present in the catalog, falling
+	// back to v2 if nothing usable is reported. "" (rather than "v2")
+	// tracks "nothing picked yet" so a v1-only catalog resolves to v1
+	// instead of being masked by the v2 fallback.
+	resolved := ""
+	for _, apiVersion := range apiVersionList {
+		if apiVersion.Status != "CURRENT" && apiVersion.Status != "SUPPORTED" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(apiVersion.ID, "v3"):
+			resolved = "v3"
+		case strings.HasPrefix(apiVersion.ID, "v2") && resolved != "v3":
+			resolved = "v2"
+		case strings.HasPrefix(apiVersion.ID, "v1") && resolved != "v2" && resolved != "v3":
+			resolved = "v1"
+		}
+	}
+	if resolved == "" {
+		resolved = "v2"
+	}
+
+	o.setResolvedBlockStorageAPIVersion(region, resolved)
+	return resolved, nil
+}
+
+// setResolvedBlockStorageAPIVersion records the detected (or configured)
+// Cinder API version for a region.
+func (o *OpenStack) setResolvedBlockStorageAPIVersion(region, version string) {
+	if o.resolvedBlockStorageAPIVersion == nil {
+		o.resolvedBlockStorageAPIVersion = map[string]string{}
+	}
+	o.resolvedBlockStorageAPIVersion[region] = version
+}
+
+// newBlockStorageClient constructs the gophercloud service client for the
+// resolved Cinder API version.
+func newBlockStorageClient(provider *gophercloud.ProviderClient, eo gophercloud.EndpointOpts, version string) (*gophercloud.ServiceClient, error) {
+	switch version {
+	case "v1":
+		return openstack.NewBlockStorageV1(provider, eo)
+	case "v3":
+		return openstack.NewBlockStorageV3(provider, eo)
+	default:
+		return openstack.NewBlockStorageV2(provider, eo)
+	}
+}
+
+// probeBlockStorageClient resolves a block storage client to use for the
+// apiversions probe, trying v3 first and falling back to v2 and then v1
+// when construction fails, so detection still works against catalogs that
+// only expose one of volumev3/volumev2/volume (starting from v2 alone would
+// fail the probe outright on a v3-only catalog, before the probe ever gets
+// a chance to see v3 is available).
+func probeBlockStorageClient(provider *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error) {
+	ctors := []func(*gophercloud.ProviderClient, gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error){
+		openstack.NewBlockStorageV3,
+		openstack.NewBlockStorageV2,
+		openstack.NewBlockStorageV1,
+	}
+
+	var err error
+	for _, ctor := range ctors {
+		var client *gophercloud.ServiceClient
+		client, err = ctor(provider, eo)
+		if err == nil {
+			return client, nil
+		}
+	}
+	return nil, err
+}
+
+// tlsConfig builds the *tls.Config used to talk to every OpenStack
+// endpoint, always trusting the system cert pool and appending tls_ca and
+// ca_bundle_path onto it, so a private CA for one endpoint doesn't drop
+// trust in a public CA another endpoint relies on. OS_CACERT and
+// OS_INSECURE are honored as fallbacks when the equivalent config option
+// isn't set, matching the Terraform Swift backend's behavior.
+func (o *OpenStack) tlsConfig() (*tls.Config, error) {
+	clientConfig := o.ClientConfig
+
+	tlsCA := clientConfig.TLSCA
+	if tlsCA == "" {
+		tlsCA = os.Getenv("OS_CACERT")
+	}
+	if !clientConfig.InsecureSkipVerify {
+		switch os.Getenv("OS_INSECURE") {
+		case "true", "1":
+			clientConfig.InsecureSkipVerify = true
+		}
+	}
+
+	// tlsint.ClientConfig.TLSConfig() treats tls_ca as replacing the system
+	// pool rather than adding to it, so resolve tls_cert/tls_key/
+	// insecure_skip_verify through it but build RootCAs ourselves below as
+	// a union of the system pool, tls_ca, and ca_bundle_path.
+	clientConfig.TLSCA = ""
+
+	tlsConfig, err := clientConfig.TLSConfig()
 	if err != nil {
-		log.Println("W! failed to get storage pools: " + err.Error())
+		return nil, err
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{InsecureSkipVerify: clientConfig.InsecureSkipVerify}
 	}
 
-	// Calculate statistics
-	// TODO perhaps make what is gathered configurable?
-	// so if a service is missing, it doesn't attempt gather?
-	// does it matter?
-	gatherIdentityStatistics(acc, projectMap)
-	gatherHypervisorStatistics(acc, hypervisorList)
-	gatherServerStatistics(acc, projectMap, flavorMap, serverList)
-	gatherVolumeStatistics(acc, projectMap, volumeList)
-	gatherStoragePoolStatistics(acc, storagePoolList)
-	// TODO if Gophercloud supports it, add some ironic stats
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	tlsConfig.RootCAs = pool
 
-	return nil
+	if tlsCA != "" {
+		pem, err := ioutil.ReadFile(tlsCA)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read tls_ca: %v", err)
+		}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("unable to parse PEM data from tls_ca %q", tlsCA)
+		}
+	}
+
+	if o.CABundlePath != "" {
+		pem, err := ioutil.ReadFile(o.CABundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca_bundle_path: %v", err)
+		}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("unable to parse PEM data from ca_bundle_path %q", o.CABundlePath)
+		}
+	}
+
+	return tlsConfig, nil
 }
 
-func getProjectMap(provider *gophercloud.ProviderClient) (ProjectMap, error) {
+func (o *OpenStack) Gather(acc telegraf.Accumulator) error {
 
-	identity, err := openstack.NewIdentityV3(provider, gophercloud.EndpointOpts{})
+	// Build the TLS transport before authenticating, so tls_ca/tls_cert/
+	// tls_key/insecure_skip_verify/ca_bundle_path apply to the Keystone
+	// auth round-trip itself and not just the catalog services gathered
+	// below.
+	tlsConfig, err := o.tlsConfig()
 	if err != nil {
-		return nil, fmt.Errorf("unable to create V3 identity client: %v", err)
+		return fmt.Errorf("unable to build TLS config: %v", err)
 	}
 
+	provider, err := openstack.NewClient(o.IdentityEndpoint)
+	if err != nil {
+		return fmt.Errorf("unable to create OpenStack provider client: %v", err)
+	}
+	provider.HTTPClient = http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+
+	// Authenticate against Keystone and get a token provider
+	authOptions := gophercloud.AuthOptions{
+		IdentityEndpoint: o.IdentityEndpoint,
+		DomainName:       o.Domain,
+		TenantName:       o.Project,
+		Username:         o.Username,
+		Password:         o.Password,
+	}
+	if err := openstack.Authenticate(provider, authOptions); err != nil {
+		return fmt.Errorf("Unable to authenticate OpenStack user: %v", err)
+	}
+
+	// Authenticate once, then fan out the gather across every configured
+	// region, tagging every metric with the region it came from.
+	for _, region := range o.regions() {
+		eo := gophercloud.EndpointOpts{
+			Region:       region,
+			Availability: o.availability(),
+		}
+
+		enabled := o.enabledServices()
+
+		// Construct 1 client per service, skipping authentication for any
+		// service that isn't enabled, and reuse it across the fan-out
+		// below instead of re-creating it inside every getter. A service
+		// whose client fails to construct is logged and left out of
+		// "ready" below so dispatch skips it instead of handing a nil
+		// client to a getter.
+		var identity, compute, blockstorage, network, baremetal *gophercloud.ServiceClient
+		var blockStorageAPIVersion string
+		ready := map[string]bool{}
+
+		if enabled["identity"] {
+			identity, err = openstack.NewIdentityV3(provider, eo)
+			if err != nil {
+				log.Println("W! unable to create V3 identity client: " + err.Error())
+			} else {
+				ready["identity"] = true
+			}
+		}
+		if enabled["compute"] || enabled["hypervisor"] {
+			compute, err = openstack.NewComputeV2(provider, eo)
+			if err != nil {
+				log.Println("W! unable to create V2 compute client: " + err.Error())
+			} else {
+				ready["compute"] = true
+				ready["hypervisor"] = true
+			}
+		}
+		if enabled["volume"] || enabled["storage_pool"] {
+			blockStorageAPIVersion, err = o.resolveBlockStorageAPIVersion(provider, eo, region)
+			if err != nil {
+				log.Println("W! failed to resolve block storage API version: " + err.Error())
+			}
+			blockstorage, err = newBlockStorageClient(provider, eo, blockStorageAPIVersion)
+			if err != nil {
+				log.Println("W! unable to create " + blockStorageAPIVersion + " volume client: " + err.Error())
+			} else {
+				ready["volume"] = true
+				ready["storage_pool"] = true
+			}
+		}
+		if enabled["network"] {
+			network, err = openstack.NewNetworkV2(provider, eo)
+			if err != nil {
+				log.Println("W! unable to create V2 network client: " + err.Error())
+			} else {
+				ready["network"] = true
+			}
+		}
+		if enabled["baremetal"] {
+			baremetal, err = openstack.NewBareMetalV1(provider, eo)
+			if err != nil {
+				log.Println("W! unable to create V1 baremetal client: " + err.Error())
+			} else {
+				ready["baremetal"] = true
+			}
+		}
+
+		// Gather resources
+		// Don't bomb out here, some data is better than none, the 'gather'
+		// functions will check for validity before continuing. Run every
+		// getter concurrently, bounded by max_concurrent_requests, and
+		// aggregate each failure independently rather than aborting.
+		var (
+			projectMap        ProjectMap
+			hypervisorList    HypervisorList
+			flavorMap         FlavorMap
+			serverList        ServerList
+			volumeList        VolumeList
+			storagePoolList   StoragePoolList
+			networkList       NetworkList
+			subnetList        SubnetList
+			routerList        RouterList
+			floatingIPList    FloatingIPList
+			securityGroupList SecurityGroupList
+			nodeList          NodeList
+		)
+
+		sem := make(chan struct{}, o.maxConcurrentRequests())
+		var g errgroup.Group
+
+		fetch := func(name string, fn func() error) {
+			g.Go(func() error {
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				if err := fn(); err != nil {
+					log.Println("W! failed to get " + name + ": " + err.Error())
+				}
+				return nil
+			})
+		}
+
+		// Dispatch table of service name -> the fetch(es) it registers,
+		// rather than a fixed sequence of get* calls, so enabled_services
+		// can select exactly what this cycle gathers.
+		dispatch := map[string]func(){
+			"identity": func() {
+				fetch("projects", func() error {
+					var err error
+					projectMap, err = getProjectMap(identity)
+					return err
+				})
+			},
+			"hypervisor": func() {
+				fetch("hypervisors", func() error {
+					var err error
+					hypervisorList, err = getHypervisorList(compute)
+					return err
+				})
+			},
+			"compute": func() {
+				fetch("flavors", func() error {
+					var err error
+					flavorMap, err = getFlavorMap(compute)
+					return err
+				})
+				fetch("servers", func() error {
+					var err error
+					serverList, err = getServerList(compute)
+					return err
+				})
+			},
+			"volume": func() {
+				fetch("volumes", func() error {
+					var err error
+					volumeList, err = getVolumeList(blockstorage, blockStorageAPIVersion)
+					return err
+				})
+			},
+			"storage_pool": func() {
+				fetch("storage pools", func() error {
+					var err error
+					storagePoolList, err = getStoragePools(blockstorage)
+					return err
+				})
+			},
+			"network": func() {
+				fetch("networks", func() error {
+					var err error
+					networkList, err = getNetworkList(network)
+					return err
+				})
+				fetch("subnets", func() error {
+					var err error
+					subnetList, err = getSubnetList(network)
+					return err
+				})
+				fetch("routers", func() error {
+					var err error
+					routerList, err = getRouterList(network)
+					return err
+				})
+				fetch("floating IPs", func() error {
+					var err error
+					floatingIPList, err = getFloatingIPList(network)
+					return err
+				})
+				fetch("security groups", func() error {
+					var err error
+					securityGroupList, err = getSecurityGroupList(network)
+					return err
+				})
+			},
+			"baremetal": func() {
+				fetch("ironic nodes", func() error {
+					var err error
+					nodeList, err = getNodeList(baremetal)
+					return err
+				})
+			},
+		}
+
+		for _, service := range allServices {
+			if !enabled[service] || !ready[service] {
+				continue
+			}
+			dispatch[service]()
+		}
+
+		// Errors are already logged per-service by fetch, so g.Wait()'s
+		// return value is always nil; only wait for the fan-out to drain.
+		g.Wait()
+
+		// Calculate statistics
+		gatherIdentityStatistics(acc, region, projectMap)
+		gatherHypervisorStatistics(acc, region, hypervisorList)
+		gatherServerStatistics(acc, region, projectMap, flavorMap, serverList)
+		gatherVolumeStatistics(acc, region, projectMap, volumeList)
+		gatherStoragePoolStatistics(acc, region, storagePoolList)
+		gatherNetworkStatistics(acc, region, projectMap, networkList, subnetList, routerList, floatingIPList, securityGroupList)
+		gatherIronicStatistics(acc, region, nodeList)
+	}
+
+	return nil
+}
+
+func getProjectMap(identity *gophercloud.ServiceClient) (ProjectMap, error) {
+
 	page, err := projects.List(identity, &projects.ListOpts{}).AllPages()
 	if err != nil {
 		return nil, fmt.Errorf("unable to list projects: %v", err)
@@ -206,12 +697,7 @@ func getProjectMap(provider *gophercloud.ProviderClient) (ProjectMap, error) {
 	return projectMap, nil
 }
 
-func getHypervisorList(provider *gophercloud.ProviderClient) (HypervisorList, error) {
-	// TODO store 1 client per service and pass into these functions
-	compute, err := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to create V2 compute client: %v", err)
-	}
+func getHypervisorList(compute *gophercloud.ServiceClient) (HypervisorList, error) {
 
 	page, err := hypervisors.List(compute).AllPages()
 	if err != nil {
@@ -226,12 +712,7 @@ func getHypervisorList(provider *gophercloud.ProviderClient) (HypervisorList, er
 	return hypervisorList, nil
 }
 
-func getFlavorMap(provider *gophercloud.ProviderClient) (FlavorMap, error) {
-
-	compute, err := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to create V2 compute client: %v", err)
-	}
+func getFlavorMap(compute *gophercloud.ServiceClient) (FlavorMap, error) {
 
 	page, err := flavors.ListDetail(compute, &flavors.ListOpts{}).AllPages()
 	if err != nil {
@@ -251,12 +732,7 @@ func getFlavorMap(provider *gophercloud.ProviderClient) (FlavorMap, error) {
 	return flavorMap, nil
 }
 
-func getServerList(provider *gophercloud.ProviderClient) (ServerList, error) {
-
-	compute, err := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to create V2 compute client: %v", err)
-	}
+func getServerList(compute *gophercloud.ServiceClient) (ServerList, error) {
 
 	page, err := servers.List(compute, &servers.ListOpts{AllTenants: true}).AllPages()
 	if err != nil {
@@ -271,46 +747,168 @@ func getServerList(provider *gophercloud.ProviderClient) (ServerList, error) {
 	return serverList, nil
 }
 
-func getVolumeList(provider *gophercloud.ProviderClient) (VolumeList, error) {
+func getVolumeList(volume *gophercloud.ServiceClient, apiVersion string) (VolumeList, error) {
+
+	s := VolumeList{}
 
-	volume, err := openstack.NewBlockStorageV2(provider, gophercloud.EndpointOpts{})
+	switch apiVersion {
+	case "v1":
+		// v1 doesn't expose the volumetenants extension, so TenantID is
+		// left unset and these volumes fall under the "" project below.
+		page, err := volumesv1.List(volume, volumesv1.ListOpts{}).AllPages()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list volumes: %v", err)
+		}
+		volumeList, err := volumesv1.ExtractVolumes(page)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract volumes: %v", err)
+		}
+		for _, v := range volumeList {
+			s = append(s, Volume{Volume: volumes.Volume{
+				ID:         v.ID,
+				Status:     v.Status,
+				Size:       v.Size,
+				VolumeType: v.VolumeType,
+			}})
+		}
+	case "v3":
+		page, err := volumesv3.List(volume, volumesv3.ListOpts{AllTenants: true}).AllPages()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list volumes: %v", err)
+		}
+		volumeList, err := volumesv3.ExtractVolumes(page)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract volumes: %v", err)
+		}
+		for _, v := range volumeList {
+			s = append(s, Volume{
+				Volume: volumes.Volume{
+					ID:         v.ID,
+					Status:     v.Status,
+					Size:       v.Size,
+					VolumeType: v.VolumeType,
+				},
+				VolumeExt: volumetenants.VolumeExt{TenantID: v.TenantID},
+			})
+		}
+	default:
+		page, err := volumes.List(volume, &volumes.ListOpts{AllTenants: true}).AllPages()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list volumes: %v", err)
+		}
+		volumes.ExtractVolumesInto(page, &s)
+	}
+
+	return s, nil
+}
+
+func getStoragePools(volume *gophercloud.ServiceClient) (StoragePoolList, error) {
+
+	results, err := schedulerstats.List(volume, &schedulerstats.ListOpts{Detail: true}).AllPages()
 	if err != nil {
-		return nil, fmt.Errorf("unable to create V2 volume client: %v", err)
+		return nil, fmt.Errorf("unable to list storage pools: %v", err)
 	}
 
-	page, err := volumes.List(volume, &volumes.ListOpts{AllTenants: true}).AllPages()
+	storagePoolList, err := schedulerstats.ExtractStoragePools(results)
 	if err != nil {
-		return nil, fmt.Errorf("unable to list volumes: %v", err)
+		return nil, fmt.Errorf("unable to extract storage pools: %v", err)
 	}
 
-	s := VolumeList{}
-	volumes.ExtractVolumesInto(page, &s)
+	return storagePoolList, nil
 
-	return s, nil
 }
 
-func getStoragePools(provider *gophercloud.ProviderClient) (StoragePoolList, error) {
+func getNetworkList(network *gophercloud.ServiceClient) (NetworkList, error) {
 
-	volume, err := openstack.NewBlockStorageV2(provider, gophercloud.EndpointOpts{})
+	page, err := networks.List(network, networks.ListOpts{}).AllPages()
 	if err != nil {
-		return nil, fmt.Errorf("unable to create V2 volume client: %v", err)
+		return nil, fmt.Errorf("unable to list networks: %v", err)
 	}
 
-	results, err := schedulerstats.List(volume, &schedulerstats.ListOpts{Detail: true}).AllPages()
+	networkList, err := networks.ExtractNetworks(page)
 	if err != nil {
-		return nil, fmt.Errorf("unable to list storage pools: %v", err)
+		return nil, fmt.Errorf("unable to extract networks: %v", err)
 	}
 
-	storagePoolList, err := schedulerstats.ExtractStoragePools(results)
+	return networkList, nil
+}
+
+func getSubnetList(network *gophercloud.ServiceClient) (SubnetList, error) {
+
+	page, err := subnets.List(network, subnets.ListOpts{}).AllPages()
 	if err != nil {
-		return nil, fmt.Errorf("unable to extract storage pools: %v", err)
+		return nil, fmt.Errorf("unable to list subnets: %v", err)
 	}
 
-	return storagePoolList, nil
+	subnetList, err := subnets.ExtractSubnets(page)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract subnets: %v", err)
+	}
+
+	return subnetList, nil
+}
+
+func getRouterList(network *gophercloud.ServiceClient) (RouterList, error) {
+
+	page, err := routers.List(network, routers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list routers: %v", err)
+	}
+
+	routerList, err := routers.ExtractRouters(page)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract routers: %v", err)
+	}
+
+	return routerList, nil
+}
+
+func getFloatingIPList(network *gophercloud.ServiceClient) (FloatingIPList, error) {
+
+	page, err := floatingips.List(network, floatingips.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list floating IPs: %v", err)
+	}
+
+	floatingIPList, err := floatingips.ExtractFloatingIPs(page)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract floating IPs: %v", err)
+	}
+
+	return floatingIPList, nil
+}
+
+func getSecurityGroupList(network *gophercloud.ServiceClient) (SecurityGroupList, error) {
+
+	page, err := groups.List(network, groups.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list security groups: %v", err)
+	}
+
+	securityGroupList, err := groups.ExtractGroups(page)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract security groups: %v", err)
+	}
 
+	return securityGroupList, nil
 }
 
-func gatherIdentityStatistics(acc telegraf.Accumulator, projectMap ProjectMap) {
+func getNodeList(baremetal *gophercloud.ServiceClient) (NodeList, error) {
+
+	page, err := nodes.List(baremetal, nodes.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list ironic nodes: %v", err)
+	}
+
+	nodeList, err := nodes.ExtractNodes(page)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract ironic nodes: %v", err)
+	}
+
+	return nodeList, nil
+}
+
+func gatherIdentityStatistics(acc telegraf.Accumulator, region string, projectMap ProjectMap) {
 	// TODO check for nil in Gather instead before calling function
 	// Ignore if any required data is missing
 	if projectMap == nil {
@@ -321,11 +919,11 @@ func gatherIdentityStatistics(acc telegraf.Accumulator, projectMap ProjectMap) {
 		fields := FieldMap{
 			"projects": len(projectMap),
 		}
-		acc.AddFields("openstack_identity_total", fields, TagMap{})
+		acc.AddFields("openstack_identity_total", fields, TagMap{"region": region})
 	}
 }
 
-func gatherHypervisorStatistics(acc telegraf.Accumulator, hypervisorList HypervisorList) {
+func gatherHypervisorStatistics(acc telegraf.Accumulator, region string, hypervisorList HypervisorList) {
 
 	// Ignore if any required data is missing
 	if hypervisorList == nil {
@@ -349,6 +947,7 @@ func gatherHypervisorStatistics(acc telegraf.Accumulator, hypervisorList Hypervi
 		// Dump per hypervisor statistics
 		tags := TagMap{
 			"hypervisor": hypervisor.HypervisorHostname,
+			"region":     region,
 		}
 		fields := FieldMap{
 			"memory_mb":      hypervisor.MemoryMB,
@@ -364,11 +963,11 @@ func gatherHypervisorStatistics(acc telegraf.Accumulator, hypervisorList Hypervi
 	// "overall statistics"?
 	// Dump overall hypervisor statistics
 	if len(totals) != 0 {
-		acc.AddFields("openstack_hypervisor_total", totals.encode(), TagMap{})
+		acc.AddFields("openstack_hypervisor_total", totals.encode(), TagMap{"region": region})
 	}
 }
 
-func gatherServerStatistics(acc telegraf.Accumulator, projectMap ProjectMap, flavorMap FlavorMap, serverList ServerList) {
+func gatherServerStatistics(acc telegraf.Accumulator, region string, projectMap ProjectMap, flavorMap FlavorMap, serverList ServerList) {
 
 	// Ignore if any required data is missing
 	if projectMap == nil || flavorMap == nil || serverList == nil {
@@ -420,21 +1019,22 @@ func gatherServerStatistics(acc telegraf.Accumulator, projectMap ProjectMap, fla
 
 	// Dump overall server states
 	if len(overallStateFields) != 0 {
-		acc.AddFields("openstack_server_state_total", overallStateFields.encode(), TagMap{})
-		acc.AddFields("openstack_server_stats_total", overallFields.encode(), TagMap{})
+		acc.AddFields("openstack_server_state_total", overallStateFields.encode(), TagMap{"region": region})
+		acc.AddFields("openstack_server_stats_total", overallFields.encode(), TagMap{"region": region})
 	}
 
 	// Dump per-project server states
 	for project, fields := range projectStateFields {
 		tags := TagMap{
 			"project": project,
+			"region":  region,
 		}
 		acc.AddFields("openstack_server_state", fields.encode(), tags)
 		acc.AddFields("openstack_server_stats", projectFields[project].encode(), tags)
 	}
 }
 
-func gatherVolumeStatistics(acc telegraf.Accumulator, projectMap ProjectMap, volumeList VolumeList) {
+func gatherVolumeStatistics(acc telegraf.Accumulator, region string, projectMap ProjectMap, volumeList VolumeList) {
 
 	// Ignore if any required data is missing
 	if projectMap == nil || volumeList == nil {
@@ -473,21 +1073,22 @@ func gatherVolumeStatistics(acc telegraf.Accumulator, projectMap ProjectMap, vol
 
 	// Dump overall statistics
 	if len(overallCount) != 0 {
-		acc.AddFields("openstack_volume_count_total", overallCount.encode(), TagMap{})
-		acc.AddFields("openstack_volume_size_total", overallSizes.encode(), TagMap{})
+		acc.AddFields("openstack_volume_count_total", overallCount.encode(), TagMap{"region": region})
+		acc.AddFields("openstack_volume_size_total", overallSizes.encode(), TagMap{"region": region})
 	}
 
 	// Dump per-project statistics
 	for project, count := range projectCount {
 		tags := TagMap{
 			"project": project,
+			"region":  region,
 		}
 		acc.AddFields("openstack_volume_count", count.encode(), tags)
 		acc.AddFields("openstack_volume_size", projectSizes[project].encode(), tags)
 	}
 }
 
-func gatherStoragePoolStatistics(acc telegraf.Accumulator, storagePoolList StoragePoolList) {
+func gatherStoragePoolStatistics(acc telegraf.Accumulator, region string, storagePoolList StoragePoolList) {
 
 	// Ignore if any required data is missing
 	if storagePoolList == nil {
@@ -496,7 +1097,8 @@ func gatherStoragePoolStatistics(acc telegraf.Accumulator, storagePoolList Stora
 
 	for _, storagePool := range storagePoolList {
 		tags := TagMap{
-			"name": storagePool.Capabilities.VolumeBackendName,
+			"name":   storagePool.Capabilities.VolumeBackendName,
+			"region": region,
 		}
 		fields := FieldMap{
 			"total_capacity_gb": storagePool.Capabilities.TotalCapacityGB,
@@ -506,3 +1108,149 @@ func gatherStoragePoolStatistics(acc telegraf.Accumulator, storagePoolList Stora
 	}
 
 }
+
+func gatherNetworkStatistics(acc telegraf.Accumulator, region string, projectMap ProjectMap, networkList NetworkList, subnetList SubnetList, routerList RouterList, floatingIPList FloatingIPList, securityGroupList SecurityGroupList) {
+
+	// Ignore if any required data is missing
+	if projectMap == nil {
+		return
+	}
+
+	if networkList != nil {
+		for _, network := range networkList {
+			tags := TagMap{
+				"project": projectMap[network.TenantID].Name,
+				"status":  strings.ToLower(network.Status),
+				"region":  region,
+			}
+			fields := FieldMap{
+				"subnets": len(network.Subnets),
+				"shared":  network.Shared,
+			}
+			acc.AddFields("openstack_network", fields, tags)
+		}
+	}
+
+	if subnetList != nil {
+		overallSubnets := IntegerFieldMap{
+			"subnets": len(subnetList),
+		}
+		acc.AddFields("openstack_subnet_total", overallSubnets.encode(), TagMap{"region": region})
+
+		projectSubnets := KeyedIntegerFieldMap{}
+		for _, subnet := range subnetList {
+			project := projectMap[subnet.TenantID].Name
+			if _, ok := projectSubnets[project]; !ok {
+				projectSubnets[project] = IntegerFieldMap{}
+			}
+			projectSubnets[project]["subnets"] += 1
+		}
+		for project, fields := range projectSubnets {
+			tags := TagMap{
+				"project": project,
+				"region":  region,
+			}
+			acc.AddFields("openstack_subnet", fields.encode(), tags)
+		}
+	}
+
+	if routerList != nil {
+		for _, router := range routerList {
+			tags := TagMap{
+				"project": projectMap[router.TenantID].Name,
+				"status":  strings.ToLower(router.Status),
+				"region":  region,
+			}
+			fields := FieldMap{
+				"external_fixed_ips": len(router.GatewayInfo.ExternalFixedIPs),
+			}
+			acc.AddFields("openstack_router", fields, tags)
+		}
+	}
+
+	if floatingIPList != nil {
+		overallCount := IntegerFieldMap{}
+		for _, floatingIP := range floatingIPList {
+			status := strings.ToLower(floatingIP.Status)
+			overallCount[status] += 1
+
+			tags := TagMap{
+				"project": projectMap[floatingIP.TenantID].Name,
+				"status":  status,
+				"region":  region,
+			}
+			fields := FieldMap{
+				"floating_ip_address": floatingIP.FloatingIP,
+			}
+			acc.AddFields("openstack_floatingip", fields, tags)
+		}
+		if len(overallCount) != 0 {
+			acc.AddFields("openstack_floatingip_total", overallCount.encode(), TagMap{"region": region})
+		}
+	}
+
+	if securityGroupList != nil {
+		overallSecurityGroups := IntegerFieldMap{
+			"security_groups": len(securityGroupList),
+		}
+		acc.AddFields("openstack_security_group_total", overallSecurityGroups.encode(), TagMap{"region": region})
+
+		projectSecurityGroups := KeyedIntegerFieldMap{}
+		for _, group := range securityGroupList {
+			project := projectMap[group.TenantID].Name
+			if _, ok := projectSecurityGroups[project]; !ok {
+				projectSecurityGroups[project] = IntegerFieldMap{}
+			}
+			projectSecurityGroups[project]["security_groups"] += 1
+		}
+		for project, fields := range projectSecurityGroups {
+			tags := TagMap{
+				"project": project,
+				"region":  region,
+			}
+			acc.AddFields("openstack_security_group", fields.encode(), tags)
+		}
+	}
+}
+
+func gatherIronicStatistics(acc telegraf.Accumulator, region string, nodeList NodeList) {
+
+	// Ignore if any required data is missing
+	if nodeList == nil {
+		return
+	}
+
+	totals := IntegerFieldMap{}
+
+	for _, node := range nodeList {
+		totals[strings.ToLower(node.ProvisionState)] += 1
+
+		// Tag with the node's own UUID, matching the convention
+		// gatherHypervisorStatistics uses for hypervisor hostname, so
+		// each node stays a distinct series instead of colliding with
+		// every other node sharing the same state/driver/power/maintenance.
+		tags := TagMap{
+			"node":            node.UUID,
+			"provision_state": node.ProvisionState,
+			"power_state":     node.PowerState,
+			"maintenance":     strconv.FormatBool(node.Maintenance),
+			"driver":          node.Driver,
+			"region":          region,
+		}
+		fields := FieldMap{
+			"name": node.Name,
+		}
+		acc.AddFields("openstack_ironic_node", fields, tags)
+	}
+
+	for state, count := range totals {
+		tags := TagMap{
+			"state":  state,
+			"region": region,
+		}
+		fields := FieldMap{
+			"count": count,
+		}
+		acc.AddFields("openstack_ironic_node_total", fields, tags)
+	}
+}